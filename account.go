@@ -0,0 +1,42 @@
+package robinhood
+
+import "context"
+
+// AccountService provides access to the authenticated user's brokerage
+// accounts.
+type AccountService service
+
+// Account is a Robinhood brokerage account.
+type Account struct {
+	URL           string `json:"url"`
+	AccountNumber string `json:"account_number"`
+	BuyingPower   string `json:"buying_power"`
+	Cash          string `json:"cash"`
+	PortfolioCash string `json:"portfolio_cash"`
+}
+
+type accountsResponse struct {
+	Results []Account `json:"results"`
+}
+
+// GetAccounts returns the brokerage accounts belonging to the
+// authenticated user.
+func (s *AccountService) GetAccounts() ([]Account, error) {
+	return s.GetAccountsContext(context.Background())
+}
+
+// GetAccountsContext is the context-aware form of GetAccounts.
+func (s *AccountService) GetAccountsContext(ctx context.Context) ([]Account, error) {
+	req, err := s.client.NewRequestWithContext(ctx, "GET", "accounts/", nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var out accountsResponse
+	_, err = s.client.DoContext(ctx, req, &out)
+	if err != nil {
+		return nil, err
+	}
+
+	return out.Results, nil
+}
@@ -0,0 +1,351 @@
+package robinhood
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"time"
+)
+
+// oauthClientID is the client_id Robinhood's official iOS app sends with
+// every OAuth2 request. It is not a secret; it merely identifies the
+// calling application to Robinhood.
+const oauthClientID = "c82SH0WZOsabOXGP2sxqcj34FxkvfnWRZBKlBjFS"
+
+const oauthTokenPath = "oauth2/token/"
+
+// Token is an OAuth2 bearer token pair as returned by Robinhood's
+// /oauth2/token/ endpoint.
+type Token struct {
+	AccessToken  string
+	RefreshToken string
+	ExpiresAt    time.Time
+	Scope        string
+}
+
+// Expired reports whether the token is at or past its expiry. A zero
+// ExpiresAt is treated as never expiring.
+func (t Token) Expired() bool {
+	return !t.ExpiresAt.IsZero() && !time.Now().Before(t.ExpiresAt)
+}
+
+// TokenSource produces Tokens on demand and knows how to refresh them.
+// Client calls Token lazily on first use and Refresh once after a 401.
+type TokenSource interface {
+	Token(ctx context.Context) (Token, error)
+	Refresh(ctx context.Context, tok Token) (Token, error)
+}
+
+// TokenStore persists a Token so a process can resume without a full
+// re-authentication.
+type TokenStore interface {
+	Load() (Token, error)
+	Save(Token) error
+}
+
+// fileTokenStore is the default TokenStore, storing the token as JSON at a
+// path on disk.
+type fileTokenStore struct {
+	path string
+}
+
+// NewFileTokenStore returns a TokenStore that reads and writes Tokens as
+// JSON at path.
+func NewFileTokenStore(path string) TokenStore {
+	return &fileTokenStore{path: path}
+}
+
+func (s *fileTokenStore) Load() (Token, error) {
+	var t Token
+	f, err := os.Open(s.path)
+	if err != nil {
+		return t, err
+	}
+	defer f.Close()
+	err = json.NewDecoder(f).Decode(&t)
+	return t, err
+}
+
+func (s *fileTokenStore) Save(t Token) error {
+	f, err := os.OpenFile(s.path, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0600)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	return json.NewEncoder(f).Encode(t)
+}
+
+// oauthTokenResponse is the raw shape of Robinhood's /oauth2/token/ response.
+type oauthTokenResponse struct {
+	AccessToken  string `json:"access_token"`
+	RefreshToken string `json:"refresh_token"`
+	ExpiresIn    int    `json:"expires_in"`
+	Scope        string `json:"scope"`
+	Mfa          struct {
+		ChallengeType string `json:"type"`
+	} `json:"mfa_required"`
+}
+
+func (r oauthTokenResponse) toToken() Token {
+	tok := Token{
+		AccessToken:  r.AccessToken,
+		RefreshToken: r.RefreshToken,
+		Scope:        r.Scope,
+	}
+	if r.ExpiresIn > 0 {
+		tok.ExpiresAt = time.Now().Add(time.Duration(r.ExpiresIn) * time.Second)
+	}
+	return tok
+}
+
+// requestOAuthToken POSTs form data to /oauth2/token/ and decodes the
+// resulting Token.
+func requestOAuthToken(ctx context.Context, c *Client, data url.Values) (Token, error) {
+	fullURL, err := c.resolveUrl(oauthTokenPath)
+	if err != nil {
+		return Token{}, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "POST", fullURL, strings.NewReader(data.Encode()))
+	if err != nil {
+		return Token{}, err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return Token{}, err
+	}
+	defer resp.Body.Close()
+
+	if respErr := c.CheckResponse(resp); respErr != nil {
+		return Token{}, respErr
+	}
+
+	var body oauthTokenResponse
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		return Token{}, err
+	}
+
+	return body.toToken(), nil
+}
+
+// AuthenticationService manages login and the OAuth2 token lifecycle.
+type AuthenticationService service
+
+// Login obtains a Token from the Client's TokenSource (by default, a
+// password login with the UserName and Password configured on Client) and
+// stores it. It is kept as a method on AuthenticationService for callers
+// that want to force an eager login.
+func (s *AuthenticationService) Login() error {
+	return s.LoginContext(context.Background())
+}
+
+// LoginContext is the context-aware form of Login.
+func (s *AuthenticationService) LoginContext(ctx context.Context) error {
+	tok, err := s.client.tokenSource.Token(ctx)
+	if err != nil {
+		return err
+	}
+	s.client.setToken(tok)
+	return nil
+}
+
+// PasswordTokenSource implements the username/password grant. It is the
+// TokenSource Client uses by default.
+type PasswordTokenSource struct {
+	client   *Client
+	username string
+	password string
+}
+
+// NewPasswordTokenSource builds a TokenSource that logs in with a
+// Robinhood username and password.
+func NewPasswordTokenSource(c *Client, username, password string) *PasswordTokenSource {
+	return &PasswordTokenSource{client: c, username: username, password: password}
+}
+
+func (p *PasswordTokenSource) Token(ctx context.Context) (Token, error) {
+	data := url.Values{
+		"grant_type": {"password"},
+		"client_id":  {oauthClientID},
+		"username":   {p.username},
+		"password":   {p.password},
+		"scope":      {"internal"},
+	}
+	return requestOAuthToken(ctx, p.client, data)
+}
+
+func (p *PasswordTokenSource) Refresh(ctx context.Context, tok Token) (Token, error) {
+	if tok.RefreshToken == "" {
+		return p.Token(ctx)
+	}
+	data := url.Values{
+		"grant_type":    {"refresh_token"},
+		"client_id":     {oauthClientID},
+		"refresh_token": {tok.RefreshToken},
+		"scope":         {"internal"},
+	}
+	return requestOAuthToken(ctx, p.client, data)
+}
+
+// StaticTokenSource wraps a Token a caller already has, refusing to
+// refresh it. Useful for users who obtained a bearer token out of band.
+type StaticTokenSource struct {
+	tok Token
+}
+
+// NewStaticTokenSource returns a TokenSource that always hands back tok.
+func NewStaticTokenSource(tok Token) *StaticTokenSource {
+	return &StaticTokenSource{tok: tok}
+}
+
+func (s *StaticTokenSource) Token(ctx context.Context) (Token, error) {
+	return s.tok, nil
+}
+
+func (s *StaticTokenSource) Refresh(ctx context.Context, tok Token) (Token, error) {
+	return Token{}, fmt.Errorf("robinhood: StaticTokenSource cannot refresh, supply a new token")
+}
+
+// deviceChallengeResponse mirrors the body Robinhood returns while a
+// device-approval challenge is pending.
+type deviceChallengeResponse struct {
+	ChallengeID string `json:"id"`
+	Status      string `json:"status"`
+}
+
+// DeviceTokenSource implements Robinhood's device-challenge MFA flow: it
+// requests a challenge (an SMS or email code the user must approve) and
+// polls until the challenge is resolved before completing the password
+// grant.
+type DeviceTokenSource struct {
+	client       *Client
+	username     string
+	password     string
+	deviceToken  string
+	PollInterval time.Duration
+	PollTimeout  time.Duration
+}
+
+// NewDeviceTokenSource builds a TokenSource that performs Robinhood's
+// device-challenge MFA flow. deviceToken is a stable, caller-generated
+// UUID identifying this device across logins.
+func NewDeviceTokenSource(c *Client, username, password, deviceToken string) *DeviceTokenSource {
+	return &DeviceTokenSource{
+		client:       c,
+		username:     username,
+		password:     password,
+		deviceToken:  deviceToken,
+		PollInterval: 2 * time.Second,
+		PollTimeout:  2 * time.Minute,
+	}
+}
+
+func (d *DeviceTokenSource) Token(ctx context.Context) (Token, error) {
+	data := url.Values{
+		"grant_type":   {"password"},
+		"client_id":    {oauthClientID},
+		"username":     {d.username},
+		"password":     {d.password},
+		"device_token": {d.deviceToken},
+		"scope":        {"internal"},
+	}
+
+	fullURL, err := d.client.resolveUrl(oauthTokenPath)
+	if err != nil {
+		return Token{}, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "POST", fullURL, strings.NewReader(data.Encode()))
+	if err != nil {
+		return Token{}, err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	resp, err := d.client.client.Do(req)
+	if err != nil {
+		return Token{}, err
+	}
+	defer resp.Body.Close()
+
+	if respErr := d.client.CheckResponse(resp); respErr != nil {
+		return Token{}, respErr
+	}
+
+	var challenge deviceChallengeResponse
+	if err := json.NewDecoder(resp.Body).Decode(&challenge); err != nil {
+		return Token{}, err
+	}
+	if challenge.ChallengeID == "" {
+		// No MFA challenge was issued; the response was already a token.
+		return requestOAuthToken(ctx, d.client, data)
+	}
+
+	if err := d.awaitApproval(ctx, challenge.ChallengeID); err != nil {
+		return Token{}, err
+	}
+
+	return requestOAuthToken(ctx, d.client, data)
+}
+
+func (d *DeviceTokenSource) awaitApproval(ctx context.Context, challengeID string) error {
+	deadline := time.Now().Add(d.PollTimeout)
+	path := fmt.Sprintf("challenge/%s/", challengeID)
+
+	for time.Now().Before(deadline) {
+		fullURL, err := d.client.resolveUrl(path)
+		if err != nil {
+			return err
+		}
+
+		req, err := http.NewRequestWithContext(ctx, "GET", fullURL, nil)
+		if err != nil {
+			return err
+		}
+
+		resp, err := d.client.client.Do(req)
+		if err != nil {
+			return err
+		}
+
+		var challenge deviceChallengeResponse
+		err = json.NewDecoder(resp.Body).Decode(&challenge)
+		resp.Body.Close()
+		if err != nil {
+			return err
+		}
+
+		switch challenge.Status {
+		case "validated":
+			return nil
+		case "failed", "expired":
+			return fmt.Errorf("robinhood: device challenge %s", challenge.Status)
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(d.PollInterval):
+		}
+	}
+
+	return fmt.Errorf("robinhood: device challenge %s timed out waiting for approval", challengeID)
+}
+
+func (d *DeviceTokenSource) Refresh(ctx context.Context, tok Token) (Token, error) {
+	if tok.RefreshToken == "" {
+		return d.Token(ctx)
+	}
+	data := url.Values{
+		"grant_type":    {"refresh_token"},
+		"client_id":     {oauthClientID},
+		"refresh_token": {tok.RefreshToken},
+		"scope":         {"internal"},
+	}
+	return requestOAuthToken(ctx, d.client, data)
+}
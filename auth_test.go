@@ -0,0 +1,73 @@
+package robinhood
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync/atomic"
+	"testing"
+)
+
+// fakeTokenSource issues token-v1 from Token and token-v2 from Refresh, so
+// tests can tell which call produced the token currently in use.
+type fakeTokenSource struct {
+	tokenCalls   int32
+	refreshCalls int32
+}
+
+func (f *fakeTokenSource) Token(ctx context.Context) (Token, error) {
+	atomic.AddInt32(&f.tokenCalls, 1)
+	return Token{AccessToken: "token-v1"}, nil
+}
+
+func (f *fakeTokenSource) Refresh(ctx context.Context, tok Token) (Token, error) {
+	atomic.AddInt32(&f.refreshCalls, 1)
+	return Token{AccessToken: "token-v2"}, nil
+}
+
+func TestClientDoRefreshesAndRetriesOn401(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.Header.Get("Authorization") {
+		case "Bearer token-v1":
+			w.WriteHeader(http.StatusUnauthorized)
+			json.NewEncoder(w).Encode(map[string]interface{}{})
+		case "Bearer token-v2":
+			json.NewEncoder(w).Encode(Order{URL: fmt.Sprintf("http://%s/orders/order1/", r.Host), State: "confirmed"})
+		default:
+			t.Errorf("unexpected Authorization header %q", r.Header.Get("Authorization"))
+			w.WriteHeader(http.StatusBadRequest)
+		}
+	}))
+	t.Cleanup(server.Close)
+
+	c := NewClient("user", "pass")
+	src := &fakeTokenSource{}
+	c.SetTokenSource(src)
+	base, err := url.Parse(server.URL + "/")
+	if err != nil {
+		t.Fatalf("parsing test server URL: %v", err)
+	}
+	c.BaseURL = base
+
+	var out Order
+	_, err = c.PostContext(context.Background(), "orders/", url.Values{}, &out)
+	if err != nil {
+		t.Fatalf("PostContext: %v", err)
+	}
+	if out.State != "confirmed" {
+		t.Fatalf("expected the retried request to succeed, got %+v", out)
+	}
+
+	if atomic.LoadInt32(&src.tokenCalls) != 1 {
+		t.Fatalf("expected exactly one initial Token call, got %d", src.tokenCalls)
+	}
+	if atomic.LoadInt32(&src.refreshCalls) != 1 {
+		t.Fatalf("expected exactly one Refresh call after the 401, got %d", src.refreshCalls)
+	}
+	if c.AuthToken != "token-v2" {
+		t.Fatalf("expected the client's stored token to be updated to token-v2, got %q", c.AuthToken)
+	}
+}
@@ -0,0 +1,299 @@
+package robinhood
+
+import (
+	"context"
+	"crypto/rand"
+	"fmt"
+	"net/url"
+)
+
+// newRefID generates a random v4 UUID suitable for use as Robinhood's
+// ref_id, the client-generated idempotency key that lets a retried order
+// submission be recognized as a duplicate instead of placed twice.
+func newRefID() (string, error) {
+	var b [16]byte
+	if _, err := rand.Read(b[:]); err != nil {
+		return "", err
+	}
+	b[6] = (b[6] & 0x0F) | 0x40 // version 4
+	b[8] = (b[8] & 0x3F) | 0x80 // variant 10
+	return fmt.Sprintf("%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:16]), nil
+}
+
+// LinkedOrder is an order composed of several linked legs — a bracket's
+// entry plus take-profit and stop-loss, or an OCO pair — placed together
+// and canceled together by Cancel.
+type LinkedOrder struct {
+	client *Client
+
+	// Entry is the order that opens the position. For an OCO pair, it is
+	// the first of the two linked orders.
+	Entry *Order
+	// Legs are the orders linked to Entry: take-profit and stop-loss for
+	// a bracket, or the second order for an OCO pair.
+	Legs []*Order
+}
+
+// Cancel cancels Entry and every leg. It attempts all of them even if one
+// fails, returning the first error encountered.
+func (o *LinkedOrder) Cancel(ctx context.Context) error {
+	var firstErr error
+	orders := append([]*Order{o.Entry}, o.Legs...)
+	for _, ord := range orders {
+		if ord == nil {
+			continue
+		}
+		if err := o.client.Trades.CancelOrderContext(ctx, ord.URL); err != nil && firstErr == nil {
+			firstErr = err
+		}
+	}
+	return firstErr
+}
+
+// BracketParams describes an entry order plus its linked take-profit and
+// stop-loss legs.
+type BracketParams struct {
+	AccountURL    string
+	InstrumentURL string
+	Symbol        string
+	Side          string // "buy" or "sell"
+	Quantity      int
+	EntryType     string // "market" or "limit"
+	EntryPrice    string // required when EntryType is "limit"
+
+	TakeProfitPrice string
+	StopPrice       string
+	StopLimitPrice  string // optional; empty submits the stop leg as a stop-market order
+}
+
+// PlaceBracketOrder submits an entry order plus linked take-profit and
+// stop-loss legs, returning a LinkedOrder whose Cancel cancels all three.
+// Cancel sends one cancel request per leg, in turn; it is not atomic, and
+// legs canceled before a failing one stay canceled.
+func (s *TradeService) PlaceBracketOrder(ctx context.Context, p BracketParams) (*LinkedOrder, error) {
+	exitSide := "sell"
+	if p.Side == "sell" {
+		exitSide = "buy"
+	}
+
+	ocoGroup, err := newRefID()
+	if err != nil {
+		return nil, err
+	}
+
+	linked := &LinkedOrder{client: s.client}
+
+	entry, err := s.placeOrder(ctx, orderRequest{
+		AccountURL:    p.AccountURL,
+		InstrumentURL: p.InstrumentURL,
+		Symbol:        p.Symbol,
+		Side:          p.Side,
+		Type:          p.EntryType,
+		Quantity:      p.Quantity,
+		Price:         p.EntryPrice,
+	})
+	if err != nil {
+		return linked, err
+	}
+	linked.Entry = entry
+
+	takeProfit, err := s.placeOrder(ctx, orderRequest{
+		AccountURL:    p.AccountURL,
+		InstrumentURL: p.InstrumentURL,
+		Symbol:        p.Symbol,
+		Side:          exitSide,
+		Type:          "limit",
+		Quantity:      p.Quantity,
+		Price:         p.TakeProfitPrice,
+		OCOGroup:      ocoGroup,
+	})
+	if err != nil {
+		linked.Cancel(ctx)
+		return linked, err
+	}
+	linked.Legs = append(linked.Legs, takeProfit)
+
+	stopType := "stop_loss"
+	stopPrice := p.StopLimitPrice
+	stopLoss, err := s.placeOrder(ctx, orderRequest{
+		AccountURL:    p.AccountURL,
+		InstrumentURL: p.InstrumentURL,
+		Symbol:        p.Symbol,
+		Side:          exitSide,
+		Type:          stopType,
+		Quantity:      p.Quantity,
+		Price:         stopPrice,
+		StopPrice:     p.StopPrice,
+		OCOGroup:      ocoGroup,
+	})
+	if err != nil {
+		linked.Cancel(ctx)
+		return linked, err
+	}
+	linked.Legs = append(linked.Legs, stopLoss)
+
+	return linked, nil
+}
+
+// OCOParams describes a one-cancels-other pair of orders on the same
+// instrument: when one fills, Robinhood cancels the other.
+type OCOParams struct {
+	AccountURL    string
+	InstrumentURL string
+	Symbol        string
+	Side          string
+	Quantity      int
+
+	LimitPrice string
+	StopPrice  string
+}
+
+// PlaceOCO submits a one-cancels-other pair: a limit order and a stop
+// order on the same side and quantity, linked so that a fill on either
+// cancels the other.
+func (s *TradeService) PlaceOCO(ctx context.Context, p OCOParams) (*LinkedOrder, error) {
+	refID, err := newRefID()
+	if err != nil {
+		return nil, err
+	}
+
+	linked := &LinkedOrder{client: s.client}
+
+	limitOrder, err := s.placeOrder(ctx, orderRequest{
+		AccountURL:    p.AccountURL,
+		InstrumentURL: p.InstrumentURL,
+		Symbol:        p.Symbol,
+		Side:          p.Side,
+		Type:          "limit",
+		Quantity:      p.Quantity,
+		Price:         p.LimitPrice,
+		OCOGroup:      refID,
+	})
+	if err != nil {
+		return linked, err
+	}
+	linked.Entry = limitOrder
+
+	stopOrder, err := s.placeOrder(ctx, orderRequest{
+		AccountURL:    p.AccountURL,
+		InstrumentURL: p.InstrumentURL,
+		Symbol:        p.Symbol,
+		Side:          p.Side,
+		Type:          "stop_loss",
+		Quantity:      p.Quantity,
+		StopPrice:     p.StopPrice,
+		OCOGroup:      refID,
+	})
+	if err != nil {
+		linked.Cancel(ctx)
+		return linked, err
+	}
+	linked.Legs = append(linked.Legs, stopOrder)
+
+	return linked, nil
+}
+
+// TrailingStopParams describes a trailing-stop sell (or buy-to-cover)
+// order. Exactly one of TrailPercent or TrailAmount should be set.
+type TrailingStopParams struct {
+	AccountURL    string
+	InstrumentURL string
+	Symbol        string
+	Side          string
+	Quantity      int
+
+	TrailPercent string // e.g. "5.0" for a 5% trail
+	TrailAmount  string // e.g. "1.50" for a $1.50 trail
+}
+
+// PlaceTrailingStop submits a trailing-stop order.
+func (s *TradeService) PlaceTrailingStop(ctx context.Context, p TrailingStopParams) (*Order, error) {
+	return s.placeOrder(ctx, orderRequest{
+		AccountURL:      p.AccountURL,
+		InstrumentURL:   p.InstrumentURL,
+		Symbol:          p.Symbol,
+		Side:            p.Side,
+		Type:            "stop_loss",
+		Quantity:        p.Quantity,
+		TrailingPercent: p.TrailPercent,
+		TrailingAmount:  p.TrailAmount,
+	})
+}
+
+// orderRequest holds every field the bracket/OCO/trailing helpers above may
+// need to send, so they can share one submission path.
+type orderRequest struct {
+	AccountURL      string
+	InstrumentURL   string
+	Symbol          string
+	Side            string
+	Type            string
+	Quantity        int
+	Price           string
+	StopPrice       string
+	TrailingPercent string
+	TrailingAmount  string
+	OCOGroup        string
+}
+
+// placeOrder submits an order, attaching a fresh idempotency key so a
+// retried submission after a network blip is recognized as a duplicate
+// rather than placed twice.
+func (s *TradeService) placeOrder(ctx context.Context, r orderRequest) (*Order, error) {
+	refID, err := newRefID()
+	if err != nil {
+		return nil, err
+	}
+
+	data := url.Values{
+		"account":       {r.AccountURL},
+		"instrument":    {r.InstrumentURL},
+		"symbol":        {r.Symbol},
+		"side":          {r.Side},
+		"type":          {r.Type},
+		"quantity":      {fmt.Sprintf("%d", r.Quantity)},
+		"trigger":       {"immediate"},
+		"time_in_force": {"gfd"},
+		"ref_id":        {refID},
+	}
+	if r.Price != "" {
+		data.Set("price", r.Price)
+	}
+	if r.StopPrice != "" {
+		data.Set("stop_price", r.StopPrice)
+	}
+	if r.TrailingPercent != "" {
+		data.Set("trailing_peg", "percentage")
+		data.Set("trail_amount", r.TrailingPercent)
+	} else if r.TrailingAmount != "" {
+		data.Set("trailing_peg", "price")
+		data.Set("trail_amount", r.TrailingAmount)
+	}
+	if r.OCOGroup != "" {
+		data.Set("oco_group", r.OCOGroup)
+	}
+
+	var out Order
+	_, err = s.client.PostContext(ctx, "orders/", data, &out)
+	if err != nil {
+		return nil, err
+	}
+
+	return &out, nil
+}
+
+// CancelOrder cancels a single order by its URL.
+func (s *TradeService) CancelOrder(orderURL string) error {
+	return s.CancelOrderContext(context.Background(), orderURL)
+}
+
+// CancelOrderContext is the context-aware form of CancelOrder.
+func (s *TradeService) CancelOrderContext(ctx context.Context, orderURL string) error {
+	req, err := s.client.NewRequestWithFullUrlContext(ctx, "POST", orderURL+"cancel/", nil)
+	if err != nil {
+		return err
+	}
+
+	_, err = s.client.DoContext(ctx, req, nil)
+	return err
+}
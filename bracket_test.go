@@ -0,0 +1,123 @@
+package robinhood
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// ordersTestServer fakes the orders/ and cancel endpoints used by
+// PlaceBracketOrder/PlaceOCO. failOn, if non-zero, rejects the Nth order
+// placement (1-indexed) with a 400 so tests can exercise partial-failure
+// cleanup; every order placed before that succeeds and every cancel
+// request is recorded in canceled.
+type ordersTestServer struct {
+	mu       sync.Mutex
+	placed   int
+	failOn   int
+	canceled []string
+}
+
+func newOrdersTestServer(t *testing.T, failOn int) (*httptest.Server, *ordersTestServer) {
+	t.Helper()
+	ots := &ordersTestServer{failOn: failOn}
+	var server *httptest.Server
+	server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if strings.HasSuffix(r.URL.Path, "/cancel/") {
+			ots.mu.Lock()
+			ots.canceled = append(ots.canceled, r.URL.Path)
+			ots.mu.Unlock()
+			return
+		}
+
+		ots.mu.Lock()
+		ots.placed++
+		n := ots.placed
+		ots.mu.Unlock()
+
+		if ots.failOn != 0 && n == ots.failOn {
+			w.WriteHeader(http.StatusBadRequest)
+			json.NewEncoder(w).Encode(map[string]interface{}{"detail": "rejected"})
+			return
+		}
+
+		orderURL := fmt.Sprintf("%s/orders/order%d/", server.URL, n)
+		json.NewEncoder(w).Encode(Order{URL: orderURL, State: "confirmed"})
+	}))
+	t.Cleanup(server.Close)
+
+	return server, ots
+}
+
+func newTestTradeClient(t *testing.T, server *httptest.Server) *Client {
+	t.Helper()
+	c := NewClient("user", "pass")
+	c.setToken(Token{AccessToken: "test-token"})
+	base, err := url.Parse(server.URL + "/")
+	if err != nil {
+		t.Fatalf("parsing test server URL: %v", err)
+	}
+	c.BaseURL = base
+	return c
+}
+
+func TestPlaceOCOCancelsFirstLegOnSecondLegFailure(t *testing.T) {
+	server, ots := newOrdersTestServer(t, 2)
+	client := newTestTradeClient(t, server)
+
+	linked, err := client.Trades.PlaceOCO(context.Background(), OCOParams{
+		AccountURL:    "accounts/abc/",
+		InstrumentURL: "instruments/aapl/",
+		Symbol:        "AAPL",
+		Side:          "sell",
+		Quantity:      1,
+		LimitPrice:    "200.00",
+		StopPrice:     "190.00",
+	})
+	if err == nil {
+		t.Fatal("expected an error from the rejected second leg")
+	}
+	if linked == nil || linked.Entry == nil {
+		t.Fatal("expected the first leg's order to be returned despite the second leg failing")
+	}
+
+	ots.mu.Lock()
+	defer ots.mu.Unlock()
+	if len(ots.canceled) != 1 {
+		t.Fatalf("expected the first leg to be canceled, got %d cancellations", len(ots.canceled))
+	}
+}
+
+func TestPlaceBracketOrderCancelsEarlierLegsOnFailure(t *testing.T) {
+	server, ots := newOrdersTestServer(t, 3)
+	client := newTestTradeClient(t, server)
+
+	linked, err := client.Trades.PlaceBracketOrder(context.Background(), BracketParams{
+		AccountURL:      "accounts/abc/",
+		InstrumentURL:   "instruments/aapl/",
+		Symbol:          "AAPL",
+		Side:            "buy",
+		Quantity:        1,
+		EntryType:       "market",
+		TakeProfitPrice: "210.00",
+		StopPrice:       "190.00",
+	})
+	if err == nil {
+		t.Fatal("expected an error from the rejected stop-loss leg")
+	}
+	if linked == nil || linked.Entry == nil || len(linked.Legs) != 1 {
+		t.Fatalf("expected entry + take-profit leg to be populated, got %+v", linked)
+	}
+
+	ots.mu.Lock()
+	defer ots.mu.Unlock()
+	if len(ots.canceled) != 2 {
+		t.Fatalf("expected entry and take-profit leg to both be canceled, got %d cancellations", len(ots.canceled))
+	}
+}
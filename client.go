@@ -2,13 +2,15 @@ package robinhood
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
 	"io"
-	"io/ioutil"
 	"net/http"
 	"net/url"
 	"strings"
+	"sync"
+	"time"
 )
 
 const (
@@ -23,11 +25,27 @@ type service struct {
 type Client struct {
 	client *http.Client
 
-	BaseURL   *url.URL
-	UserName  string
-	Password  string
+	BaseURL  *url.URL
+	UserName string
+	Password string
+
+	// Timeout, if positive, is applied as a per-request deadline whenever
+	// a call is made without a context deadline of its own.
+	Timeout time.Duration
+
+	// AuthToken is the access token of the current Token and is kept for
+	// callers that read it directly. Prefer configuring a TokenSource
+	// instead of writing to this field.
 	AuthToken string
 
+	tokenMu     sync.Mutex
+	token       Token
+	tokenSource TokenSource
+	tokenStore  TokenStore
+
+	// userAgent overrides the default User-Agent header when non-empty.
+	userAgent string
+
 	common service
 
 	Accounts    *AccountService
@@ -36,11 +54,13 @@ type Client struct {
 	Positions   *PositionService
 	Quotes      *QuoteService
 	Trades      *TradeService
+	Streaming   *StreamingService
 }
 
 func NewClient(username, password string) *Client {
 	baseURL, _ := url.Parse(defaultBaseURL)
-	c := &Client{client: http.DefaultClient, BaseURL: baseURL, UserName: username, Password: password}
+	c := &Client{client: &http.Client{}, BaseURL: baseURL, UserName: username, Password: password}
+	c.tokenSource = NewPasswordTokenSource(c, username, password)
 	c.common.client = c
 	c.Accounts = (*AccountService)(&c.common)
 	c.Auth = (*AuthenticationService)(&c.common)
@@ -48,26 +68,127 @@ func NewClient(username, password string) *Client {
 	c.Positions = (*PositionService)(&c.common)
 	c.Quotes = (*QuoteService)(&c.common)
 	c.Trades = (*TradeService)(&c.common)
+	c.Streaming = (*StreamingService)(&c.common)
 	return c
 }
 
+// SetTokenSource overrides the TokenSource used to obtain and refresh
+// tokens. The default, installed by NewClient, is a PasswordTokenSource
+// using UserName and Password.
+func (c *Client) SetTokenSource(src TokenSource) {
+	c.tokenMu.Lock()
+	defer c.tokenMu.Unlock()
+	c.tokenSource = src
+}
+
+// SetTokenStore installs a TokenStore that persists each Token obtained
+// from the TokenSource. When the Client has no token yet, it loads the
+// stored one before asking the TokenSource, so a later process can resume
+// without a fresh login. There is no default TokenStore; set one
+// explicitly to enable persistence.
+func (c *Client) SetTokenStore(store TokenStore) {
+	c.tokenMu.Lock()
+	defer c.tokenMu.Unlock()
+	c.tokenStore = store
+}
+
+// setToken installs tok as the current Token and, if a TokenStore is
+// configured, persists it.
+func (c *Client) setToken(tok Token) {
+	c.tokenMu.Lock()
+	c.token = tok
+	c.AuthToken = tok.AccessToken
+	store := c.tokenStore
+	c.tokenMu.Unlock()
+
+	if store != nil {
+		store.Save(tok)
+	}
+}
+
+// authToken returns the current access token. If none has been obtained
+// yet, it first tries the TokenStore (if any) and then the TokenSource.
+func (c *Client) authToken() (string, error) {
+	return c.authTokenContext(context.Background())
+}
+
+func (c *Client) authTokenContext(ctx context.Context) (string, error) {
+	c.tokenMu.Lock()
+	if c.token.AccessToken != "" {
+		tok := c.token.AccessToken
+		c.tokenMu.Unlock()
+		return tok, nil
+	}
+	src := c.tokenSource
+	store := c.tokenStore
+	c.tokenMu.Unlock()
+
+	if store != nil {
+		if saved, err := store.Load(); err == nil && saved.AccessToken != "" {
+			c.tokenMu.Lock()
+			c.token = saved
+			c.AuthToken = saved.AccessToken
+			c.tokenMu.Unlock()
+			return saved.AccessToken, nil
+		}
+	}
+
+	tok, err := src.Token(ctx)
+	if err != nil {
+		return "", err
+	}
+	c.setToken(tok)
+	return tok.AccessToken, nil
+}
+
+// refreshAuthToken attempts a single refresh of the current token via the
+// configured TokenSource. If the refresh itself fails, the stored token is
+// cleared so the next request falls back to a full re-authentication.
+func (c *Client) refreshAuthToken(ctx context.Context) error {
+	c.tokenMu.Lock()
+	cur := c.token
+	src := c.tokenSource
+	c.tokenMu.Unlock()
+
+	tok, err := src.Refresh(ctx, cur)
+	if err != nil {
+		c.tokenMu.Lock()
+		c.token = Token{}
+		c.AuthToken = ""
+		c.tokenMu.Unlock()
+		return err
+	}
+
+	c.setToken(tok)
+	return nil
+}
+
 func (c *Client) Post(urlStr string, data url.Values, v interface{}) (resp *http.Response, err error) {
+	return c.PostContext(context.Background(), urlStr, data, v)
+}
+
+// PostContext is the context-aware form of Post.
+func (c *Client) PostContext(ctx context.Context, urlStr string, data url.Values, v interface{}) (resp *http.Response, err error) {
 	fullUrl, err := c.resolveUrl(urlStr)
 	if err != nil {
 		return nil, err
 	}
 
-	req, _ := http.NewRequest("POST", fullUrl, strings.NewReader(data.Encode()))
+	req, err := http.NewRequestWithContext(ctx, "POST", fullUrl, strings.NewReader(data.Encode()))
+	if err != nil {
+		return nil, err
+	}
 	for k, v := range c.getDefaultHeaders() {
 		req.Header.Add(k, v)
 	}
 
-	if c.AuthToken == "" {
-		c.Auth.Login()
+	tok, err := c.authTokenContext(ctx)
+	if err != nil {
+		return nil, err
 	}
-	req.Header.Add("Authorization", fmt.Sprintf("Token %v", c.AuthToken))
+	req.Header.Add("Authorization", fmt.Sprintf("Bearer %v", tok))
 
-	return c.Do(req, v)
+	return c.DoContext(ctx, req, v)
 }
 
 func (c *Client) PostForm(urlStr string, data url.Values, v interface{}) (resp *http.Response, err error) {
@@ -86,16 +207,27 @@ func (c *Client) PostForm(urlStr string, data url.Values, v interface{}) (resp *
 }
 
 func (c *Client) NewRequest(method, urlStr string, body interface{}) (*http.Request, error) {
+	return c.NewRequestWithContext(context.Background(), method, urlStr, body)
+}
+
+// NewRequestWithContext is the context-aware form of NewRequest.
+func (c *Client) NewRequestWithContext(ctx context.Context, method, urlStr string, body interface{}) (*http.Request, error) {
 	fullUrl, err := c.resolveUrl(urlStr)
 
 	if err != nil {
 		return nil, err
 	}
 
-	return c.NewRequestWithFullUrl(method, fullUrl, body)
+	return c.NewRequestWithFullUrlContext(ctx, method, fullUrl, body)
 }
 
 func (c *Client) NewRequestWithFullUrl(method, fullUrl string, body interface{}) (*http.Request, error) {
+	return c.NewRequestWithFullUrlContext(context.Background(), method, fullUrl, body)
+}
+
+// NewRequestWithFullUrlContext is the context-aware form of
+// NewRequestWithFullUrl.
+func (c *Client) NewRequestWithFullUrlContext(ctx context.Context, method, fullUrl string, body interface{}) (*http.Request, error) {
 	var buf io.ReadWriter
 	if body != nil {
 		buf = &bytes.Buffer{}
@@ -105,7 +237,7 @@ func (c *Client) NewRequestWithFullUrl(method, fullUrl string, body interface{})
 		}
 	}
 
-	req, err := http.NewRequest(method, fullUrl, buf)
+	req, err := http.NewRequestWithContext(ctx, method, fullUrl, buf)
 	if err != nil {
 		return nil, err
 	}
@@ -114,10 +246,11 @@ func (c *Client) NewRequestWithFullUrl(method, fullUrl string, body interface{})
 		req.Header.Add(k, v)
 	}
 
-	if c.AuthToken == "" {
-		c.Auth.Login()
+	tok, err := c.authTokenContext(ctx)
+	if err != nil {
+		return nil, err
 	}
-	req.Header.Add("Authorization", fmt.Sprintf("Token %v", c.AuthToken))
+	req.Header.Add("Authorization", fmt.Sprintf("Bearer %v", tok))
 
 	return req, nil
 }
@@ -142,13 +275,69 @@ func (c *Client) handleResponse(resp *http.Response, v interface{}) (*http.Respo
 }
 
 func (c *Client) Do(req *http.Request, v interface{}) (*http.Response, error) {
+	return c.DoContext(req.Context(), req, v)
+}
+
+// DoContext is the context-aware form of Do. If ctx has no deadline and
+// Client.Timeout is positive, a deadline of Timeout is applied to req.
+func (c *Client) DoContext(ctx context.Context, req *http.Request, v interface{}) (*http.Response, error) {
+	ctx, cancel := c.applyTimeout(ctx)
+	defer cancel()
+	req = req.WithContext(ctx)
+
 	resp, err := c.client.Do(req)
 	if err != nil {
 		return nil, err
 	}
+
+	if resp.StatusCode == http.StatusUnauthorized {
+		resp.Body.Close()
+
+		if rerr := c.refreshAuthToken(ctx); rerr == nil {
+			retryReq, cerr := cloneRequest(req)
+			if cerr == nil {
+				tok, terr := c.authTokenContext(ctx)
+				if terr == nil {
+					retryReq.Header.Set("Authorization", fmt.Sprintf("Bearer %v", tok))
+					resp, err = c.client.Do(retryReq)
+					if err != nil {
+						return nil, err
+					}
+				}
+			}
+		}
+	}
+
 	return c.handleResponse(resp, v)
 }
 
+// cloneRequest makes a copy of req with a fresh, unconsumed body, suitable
+// for retrying a request whose body has already been sent.
+func cloneRequest(req *http.Request) (*http.Request, error) {
+	clone := req.Clone(req.Context())
+	if req.GetBody != nil {
+		body, err := req.GetBody()
+		if err != nil {
+			return nil, err
+		}
+		clone.Body = body
+	}
+	return clone, nil
+}
+
+// applyTimeout derives a context with a deadline of Client.Timeout from ctx,
+// unless ctx already carries a deadline or Timeout is unset. The returned
+// CancelFunc must always be called.
+func (c *Client) applyTimeout(ctx context.Context) (context.Context, context.CancelFunc) {
+	if c.Timeout <= 0 {
+		return ctx, func() {}
+	}
+	if _, ok := ctx.Deadline(); ok {
+		return ctx, func() {}
+	}
+	return context.WithTimeout(ctx, c.Timeout)
+}
+
 func (c *Client) resolveUrl(urlStr string) (string, error) {
 	rel, err := url.Parse(urlStr)
 
@@ -160,48 +349,19 @@ func (c *Client) resolveUrl(urlStr string) (string, error) {
 	return u.String(), err
 }
 
-type ErrorResponse struct {
-	Response *http.Response
-	Body     interface{}
-}
-
-func (r *ErrorResponse) Error() string {
-	return fmt.Sprintf("%v %v: %d %+v",
-		r.Response.Request.Method, r.Response.Request.URL,
-		r.Response.StatusCode, r.Body)
-}
-
-func (c *Client) CheckResponse(r *http.Response) *ErrorResponse {
-	s := r.StatusCode
-
-	if 200 <= s && s <= 299 {
-		return nil
-	}
-
-	if s == http.StatusUnauthorized || s == http.StatusForbidden {
-		c.AuthToken = ""
-	}
-
-	var f interface{}
-	data, err := ioutil.ReadAll(r.Body)
-	if err == nil && data != nil {
-		json.Unmarshal(data, &f)
-	}
-
-	return &ErrorResponse{
-		Response: r,
-		Body:     f,
+func (c *Client) getDefaultHeaders() map[string]string {
+	userAgent := "Robinhood/823 (iPhone; iOS 7.1.2; Scale/2.00)"
+	if c.userAgent != "" {
+		userAgent = c.userAgent
 	}
-}
 
-func (c *Client) getDefaultHeaders() map[string]string {
 	defaultHeaders := map[string]string{
 		"Accept":                  "*/*",
 		"Accept-Language":         "en;q=1, fr;q=0.9, de;q=0.8, ja;q=0.7, nl;q=0.6, it;q=0.5",
 		"Content-Type":            "application/x-www-form-urlencoded",
 		"X-Robinhood-API-Version": "1.91.1",
 		"Connection":              "keep-alive",
-		"User-Agent":              "Robinhood/823 (iPhone; iOS 7.1.2; Scale/2.00)",
+		"User-Agent":              userAgent,
 	}
 
 	return defaultHeaders
@@ -0,0 +1,163 @@
+package robinhood
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// Sentinel errors usable with errors.Is. AuthError and RateLimitError
+// unwrap to the corresponding sentinel; ValidationError and ServerError
+// have no sentinel.
+var (
+	// ErrRateLimited matches any RateLimitError.
+	ErrRateLimited = errors.New("robinhood: rate limited")
+	// ErrUnauthorized matches any AuthError.
+	ErrUnauthorized = errors.New("robinhood: unauthorized")
+)
+
+// APIError is the base error type for any non-2xx Robinhood response. The
+// more specific types below (AuthError, RateLimitError, ValidationError,
+// ServerError) embed it and add fields for their error class; CheckResponse
+// returns the most specific type it can classify a response as, falling
+// back to a bare *APIError.
+type APIError struct {
+	Response *http.Response
+	Body     interface{}
+}
+
+func (e *APIError) Error() string {
+	return fmt.Sprintf("%v %v: %d %+v",
+		e.Response.Request.Method, e.Response.Request.URL,
+		e.Response.StatusCode, e.Body)
+}
+
+// MFAChallenge describes an in-progress device-approval challenge reported
+// alongside a 401/403 response.
+type MFAChallenge struct {
+	ID   string
+	Type string
+}
+
+// AuthError is returned for 401 and 403 responses. Challenge is non-nil
+// when the response body carried MFA challenge info.
+type AuthError struct {
+	*APIError
+	Challenge *MFAChallenge
+}
+
+func (e *AuthError) Unwrap() error { return ErrUnauthorized }
+
+// RateLimitError is returned for 429 responses.
+type RateLimitError struct {
+	*APIError
+	RetryAfter time.Duration
+}
+
+func (e *RateLimitError) Unwrap() error { return ErrRateLimited }
+
+// ValidationError is returned for 400 responses whose body matches
+// Robinhood's {"field": ["msg", ...]} shape. A 400 whose body doesn't parse
+// as that shape is returned as a bare *APIError instead.
+type ValidationError struct {
+	*APIError
+	FieldErrors map[string][]string
+}
+
+// ServerError is returned for 5xx responses.
+type ServerError struct {
+	*APIError
+}
+
+// CheckResponse classifies a non-2xx response into one of AuthError,
+// RateLimitError, ValidationError, ServerError, or a generic *APIError. It
+// returns nil for 2xx responses.
+func (c *Client) CheckResponse(r *http.Response) error {
+	s := r.StatusCode
+	if 200 <= s && s <= 299 {
+		return nil
+	}
+
+	var body interface{}
+	data, err := ioutil.ReadAll(r.Body)
+	if err == nil && data != nil {
+		json.Unmarshal(data, &body)
+	}
+
+	base := &APIError{Response: r, Body: body}
+
+	switch {
+	case s == http.StatusUnauthorized || s == http.StatusForbidden:
+		return &AuthError{APIError: base, Challenge: parseMFAChallenge(body)}
+	case s == http.StatusTooManyRequests:
+		return &RateLimitError{APIError: base, RetryAfter: parseRetryAfter(r)}
+	case s == http.StatusBadRequest:
+		if fields, ok := parseFieldErrors(body); ok {
+			return &ValidationError{APIError: base, FieldErrors: fields}
+		}
+		return base
+	case 500 <= s && s <= 599:
+		return &ServerError{APIError: base}
+	default:
+		return base
+	}
+}
+
+func parseMFAChallenge(body interface{}) *MFAChallenge {
+	m, ok := body.(map[string]interface{})
+	if !ok {
+		return nil
+	}
+	mfa, ok := m["mfa_required"].(map[string]interface{})
+	if !ok {
+		return nil
+	}
+	challenge := &MFAChallenge{}
+	if id, ok := mfa["id"].(string); ok {
+		challenge.ID = id
+	}
+	if typ, ok := mfa["type"].(string); ok {
+		challenge.Type = typ
+	}
+	return challenge
+}
+
+func parseRetryAfter(r *http.Response) time.Duration {
+	ra := r.Header.Get("Retry-After")
+	if ra == "" {
+		return 0
+	}
+	if secs, err := strconv.Atoi(ra); err == nil {
+		return time.Duration(secs) * time.Second
+	}
+	return 0
+}
+
+// parseFieldErrors interprets body as Robinhood's {"field": ["msg", ...]}
+// validation-error shape. It returns ok=false if body doesn't match.
+func parseFieldErrors(body interface{}) (fields map[string][]string, ok bool) {
+	m, isMap := body.(map[string]interface{})
+	if !isMap || len(m) == 0 {
+		return nil, false
+	}
+
+	fields = make(map[string][]string, len(m))
+	for field, v := range m {
+		msgs, isSlice := v.([]interface{})
+		if !isSlice {
+			return nil, false
+		}
+		for _, msg := range msgs {
+			s, isString := msg.(string)
+			if !isString {
+				return nil, false
+			}
+			fields[field] = append(fields[field], s)
+		}
+	}
+	return fields, true
+}
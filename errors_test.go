@@ -0,0 +1,122 @@
+package robinhood
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"testing"
+)
+
+func newCheckResponseTestRequest(t *testing.T) *http.Request {
+	t.Helper()
+	u, err := url.Parse("https://api.robinhood.com/orders/")
+	if err != nil {
+		t.Fatalf("parsing test URL: %v", err)
+	}
+	return &http.Request{Method: "POST", URL: u}
+}
+
+func newCheckResponseTestResponse(t *testing.T, status int, header http.Header, body string) *http.Response {
+	t.Helper()
+	if header == nil {
+		header = http.Header{}
+	}
+	return &http.Response{
+		StatusCode: status,
+		Header:     header,
+		Request:    newCheckResponseTestRequest(t),
+		Body:       io.NopCloser(strings.NewReader(body)),
+	}
+}
+
+func TestCheckResponseClassifiesByStatus(t *testing.T) {
+	c := NewClient("user", "pass")
+
+	t.Run("2xx is nil", func(t *testing.T) {
+		resp := newCheckResponseTestResponse(t, http.StatusOK, nil, `{}`)
+		if err := c.CheckResponse(resp); err != nil {
+			t.Fatalf("expected nil, got %v", err)
+		}
+	})
+
+	t.Run("401 is AuthError with MFA challenge", func(t *testing.T) {
+		resp := newCheckResponseTestResponse(t, http.StatusUnauthorized, nil,
+			`{"mfa_required":{"id":"abc123","type":"sms"}}`)
+		err := c.CheckResponse(resp)
+
+		var authErr *AuthError
+		if !errors.As(err, &authErr) {
+			t.Fatalf("expected *AuthError, got %T: %v", err, err)
+		}
+		if authErr.Challenge == nil || authErr.Challenge.ID != "abc123" || authErr.Challenge.Type != "sms" {
+			t.Fatalf("unexpected challenge: %+v", authErr.Challenge)
+		}
+		if !errors.Is(err, ErrUnauthorized) {
+			t.Fatal("expected errors.Is(err, ErrUnauthorized) to hold")
+		}
+	})
+
+	t.Run("403 is AuthError", func(t *testing.T) {
+		resp := newCheckResponseTestResponse(t, http.StatusForbidden, nil, `{}`)
+		var authErr *AuthError
+		if !errors.As(c.CheckResponse(resp), &authErr) {
+			t.Fatalf("expected *AuthError, got %T", c.CheckResponse(resp))
+		}
+	})
+
+	t.Run("429 is RateLimitError with Retry-After", func(t *testing.T) {
+		header := http.Header{}
+		header.Set("Retry-After", "30")
+		resp := newCheckResponseTestResponse(t, http.StatusTooManyRequests, header, `{}`)
+		err := c.CheckResponse(resp)
+
+		var rlErr *RateLimitError
+		if !errors.As(err, &rlErr) {
+			t.Fatalf("expected *RateLimitError, got %T: %v", err, err)
+		}
+		if rlErr.RetryAfter != 30*1e9 {
+			t.Fatalf("expected RetryAfter of 30s, got %v", rlErr.RetryAfter)
+		}
+		if !errors.Is(err, ErrRateLimited) {
+			t.Fatal("expected errors.Is(err, ErrRateLimited) to hold")
+		}
+	})
+
+	t.Run("400 with field errors is ValidationError", func(t *testing.T) {
+		resp := newCheckResponseTestResponse(t, http.StatusBadRequest, nil,
+			`{"quantity":["must be positive"]}`)
+		err := c.CheckResponse(resp)
+
+		var valErr *ValidationError
+		if !errors.As(err, &valErr) {
+			t.Fatalf("expected *ValidationError, got %T: %v", err, err)
+		}
+		if len(valErr.FieldErrors["quantity"]) != 1 || valErr.FieldErrors["quantity"][0] != "must be positive" {
+			t.Fatalf("unexpected field errors: %+v", valErr.FieldErrors)
+		}
+	})
+
+	t.Run("400 without field-errors shape is bare APIError", func(t *testing.T) {
+		resp := newCheckResponseTestResponse(t, http.StatusBadRequest, nil, `{"detail":"bad request"}`)
+		err := c.CheckResponse(resp)
+
+		var valErr *ValidationError
+		if errors.As(err, &valErr) {
+			t.Fatalf("expected a bare *APIError, got *ValidationError: %+v", valErr)
+		}
+		var apiErr *APIError
+		if !errors.As(err, &apiErr) {
+			t.Fatalf("expected *APIError, got %T: %v", err, err)
+		}
+	})
+
+	t.Run("500 is ServerError", func(t *testing.T) {
+		resp := newCheckResponseTestResponse(t, http.StatusInternalServerError, nil, `{}`)
+		var srvErr *ServerError
+		if !errors.As(c.CheckResponse(resp), &srvErr) {
+			t.Fatalf("expected *ServerError, got %T", c.CheckResponse(resp))
+		}
+	})
+}
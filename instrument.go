@@ -0,0 +1,44 @@
+package robinhood
+
+import (
+	"context"
+	"fmt"
+)
+
+// InstrumentService provides access to Robinhood's tradable instrument
+// catalog.
+type InstrumentService service
+
+// Instrument describes a single tradable security.
+type Instrument struct {
+	URL    string `json:"url"`
+	Symbol string `json:"symbol"`
+	Name   string `json:"name"`
+}
+
+// GetInstrument looks up a single instrument by its Robinhood ID.
+func (s *InstrumentService) GetInstrument(id string) (*Instrument, error) {
+	return s.GetInstrumentContext(context.Background(), id)
+}
+
+// GetInstrumentContext is the context-aware form of GetInstrument.
+func (s *InstrumentService) GetInstrumentContext(ctx context.Context, id string) (*Instrument, error) {
+	req, err := s.client.NewRequestWithContext(ctx, "GET", fmt.Sprintf("instruments/%s/", id), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var out Instrument
+	_, err = s.client.DoContext(ctx, req, &out)
+	if err != nil {
+		return nil, err
+	}
+
+	return &out, nil
+}
+
+// ListInstruments returns a Paginator that walks Robinhood's full
+// instrument catalog, following the "next" cursor automatically.
+func (s *InstrumentService) ListInstruments(opts ...PaginatorOption) *Paginator[Instrument] {
+	return newPaginator[Instrument](s.client, "instruments/", opts...)
+}
@@ -0,0 +1,160 @@
+package robinhood
+
+import (
+	"context"
+	"log"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// roundTripperFunc adapts a function to an http.RoundTripper.
+type roundTripperFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripperFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+// RetryTransport wraps next with exponential backoff retries on 429 and 5xx
+// responses, honoring a Retry-After header when present. Requests are only
+// retried if their body can be replayed (see http.Request.GetBody).
+func RetryTransport(next http.RoundTripper, maxRetries int) http.RoundTripper {
+	return roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+		var resp *http.Response
+		var err error
+
+		for attempt := 0; ; attempt++ {
+			attemptReq := req
+			if attempt > 0 {
+				attemptReq, err = cloneRequest(req)
+				if err != nil {
+					return nil, err
+				}
+			}
+
+			resp, err = next.RoundTrip(attemptReq)
+			if err != nil || attempt >= maxRetries {
+				return resp, err
+			}
+
+			if resp.StatusCode != http.StatusTooManyRequests && resp.StatusCode < 500 {
+				return resp, nil
+			}
+
+			if req.Body != nil && req.Body != http.NoBody && req.GetBody == nil {
+				// The original body can't be replayed safely.
+				return resp, nil
+			}
+
+			wait := retryDelay(resp, attempt)
+			resp.Body.Close()
+			select {
+			case <-time.After(wait):
+			case <-req.Context().Done():
+				return nil, req.Context().Err()
+			}
+		}
+	})
+}
+
+// retryDelay honors a Retry-After header if present, otherwise backs off
+// exponentially with jitter.
+func retryDelay(resp *http.Response, attempt int) time.Duration {
+	if ra := resp.Header.Get("Retry-After"); ra != "" {
+		if secs, err := strconv.Atoi(ra); err == nil {
+			return time.Duration(secs) * time.Second
+		}
+	}
+
+	base := time.Duration(1<<uint(attempt)) * 200 * time.Millisecond
+	jitter := time.Duration(rand.Int63n(int64(base) + 1))
+	return base + jitter
+}
+
+// LoggingTransport wraps next, logging each request's method, URL, status
+// code, and latency to logger.
+func LoggingTransport(next http.RoundTripper, logger *log.Logger) http.RoundTripper {
+	return roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+		start := time.Now()
+		resp, err := next.RoundTrip(req)
+		elapsed := time.Since(start)
+
+		if err != nil {
+			logger.Printf("%s %s: error after %s: %v", req.Method, req.URL, elapsed, err)
+			return resp, err
+		}
+
+		logger.Printf("%s %s: %d in %s", req.Method, req.URL, resp.StatusCode, elapsed)
+		return resp, nil
+	})
+}
+
+// MetricsRecorder receives per-request observations. Implementations
+// typically export these as Prometheus counters/histograms; the interface
+// keeps this package free of a direct Prometheus dependency.
+type MetricsRecorder interface {
+	ObserveRequest(method, path string, statusCode int, duration time.Duration)
+}
+
+// MetricsTransport wraps next, reporting each request to recorder.
+func MetricsTransport(next http.RoundTripper, recorder MetricsRecorder) http.RoundTripper {
+	return roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+		start := time.Now()
+		resp, err := next.RoundTrip(req)
+		elapsed := time.Since(start)
+
+		status := 0
+		if resp != nil {
+			status = resp.StatusCode
+		}
+		recorder.ObserveRequest(req.Method, req.URL.Path, status, elapsed)
+
+		return resp, err
+	})
+}
+
+// RateLimiterTransport wraps next, ensuring requests to the same endpoint
+// (method + path) are spaced at least minInterval apart. Requests to
+// different endpoints are not throttled against each other.
+func RateLimiterTransport(next http.RoundTripper, minInterval time.Duration) http.RoundTripper {
+	rl := &rateLimiter{minInterval: minInterval, last: map[string]time.Time{}}
+	return roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+		if err := rl.wait(req.Context(), req.Method+" "+req.URL.Path); err != nil {
+			return nil, err
+		}
+		return next.RoundTrip(req)
+	})
+}
+
+type rateLimiter struct {
+	mu          sync.Mutex
+	minInterval time.Duration
+	last        map[string]time.Time
+}
+
+func (rl *rateLimiter) wait(ctx context.Context, key string) error {
+	rl.mu.Lock()
+	last, ok := rl.last[key]
+	now := time.Now()
+	var sleep time.Duration
+	if ok {
+		if elapsed := now.Sub(last); elapsed < rl.minInterval {
+			sleep = rl.minInterval - elapsed
+		}
+	}
+	rl.last[key] = now.Add(sleep)
+	rl.mu.Unlock()
+
+	if sleep <= 0 {
+		return nil
+	}
+
+	select {
+	case <-time.After(sleep):
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
@@ -0,0 +1,113 @@
+package robinhood
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestWithMiddlewareLastAppliedIsOutermost(t *testing.T) {
+	var order []string
+
+	tag := func(name string) func(http.RoundTripper) http.RoundTripper {
+		return func(next http.RoundTripper) http.RoundTripper {
+			return roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+				order = append(order, name)
+				return next.RoundTrip(req)
+			})
+		}
+	}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	t.Cleanup(server.Close)
+
+	c := NewClientWithOptions(
+		WithCredentials("user", "pass"),
+		WithMiddleware(tag("A")),
+		WithMiddleware(tag("B")),
+	)
+	c.setToken(Token{AccessToken: "test-token"})
+
+	req, err := http.NewRequest("GET", server.URL+"/", nil)
+	if err != nil {
+		t.Fatalf("building request: %v", err)
+	}
+	if _, err := c.client.Do(req); err != nil {
+		t.Fatalf("Do: %v", err)
+	}
+
+	if len(order) != 2 || order[0] != "B" || order[1] != "A" {
+		t.Fatalf("expected B then A (last-applied-outermost), got %v", order)
+	}
+}
+
+func TestRetryTransportHonorsContextCancellationDuringBackoff(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	t.Cleanup(server.Close)
+
+	transport := RetryTransport(http.DefaultTransport, 5)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	req, err := http.NewRequestWithContext(ctx, "POST", server.URL+"/", strings.NewReader("body"))
+	if err != nil {
+		t.Fatalf("building request: %v", err)
+	}
+
+	go func() {
+		time.Sleep(20 * time.Millisecond)
+		cancel()
+	}()
+
+	start := time.Now()
+	_, err = transport.RoundTrip(req)
+	elapsed := time.Since(start)
+
+	if err == nil {
+		t.Fatal("expected an error from the cancelled context")
+	}
+	if elapsed > time.Second {
+		t.Fatalf("RoundTrip took %s; context cancellation should have cut the backoff short", elapsed)
+	}
+}
+
+func TestRateLimiterTransportHonorsContextCancellation(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	t.Cleanup(server.Close)
+
+	transport := RateLimiterTransport(http.DefaultTransport, time.Hour)
+
+	req1, err := http.NewRequest("GET", server.URL+"/", nil)
+	if err != nil {
+		t.Fatalf("building request: %v", err)
+	}
+	if _, err := transport.RoundTrip(req1); err != nil {
+		t.Fatalf("first RoundTrip: %v", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	req2, err := http.NewRequestWithContext(ctx, "GET", server.URL+"/", nil)
+	if err != nil {
+		t.Fatalf("building request: %v", err)
+	}
+
+	go func() {
+		time.Sleep(20 * time.Millisecond)
+		cancel()
+	}()
+
+	start := time.Now()
+	_, err = transport.RoundTrip(req2)
+	elapsed := time.Since(start)
+
+	if err == nil {
+		t.Fatal("expected an error from the cancelled context")
+	}
+	if elapsed > time.Second {
+		t.Fatalf("RoundTrip took %s; context cancellation should have cut the throttle wait short", elapsed)
+	}
+}
@@ -0,0 +1,66 @@
+package robinhood
+
+import "net/http"
+
+// Option configures a Client built by NewClientWithOptions.
+type Option func(*Client)
+
+// NewClientWithOptions builds a Client from a set of Options instead of the
+// fixed username/password constructor. At minimum, callers should supply
+// WithCredentials (or their own TokenSource via SetTokenSource after
+// construction).
+func NewClientWithOptions(opts ...Option) *Client {
+	c := NewClient("", "")
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
+}
+
+// WithCredentials sets the username and password used by the default
+// PasswordTokenSource.
+func WithCredentials(username, password string) Option {
+	return func(c *Client) {
+		c.UserName = username
+		c.Password = password
+		c.tokenSource = NewPasswordTokenSource(c, username, password)
+	}
+}
+
+// WithHTTPClient replaces the *http.Client used to make requests.
+func WithHTTPClient(hc *http.Client) Option {
+	return func(c *Client) {
+		c.client = hc
+	}
+}
+
+// WithTransport sets the base http.RoundTripper requests are sent through.
+func WithTransport(rt http.RoundTripper) Option {
+	return func(c *Client) {
+		c.client.Transport = rt
+	}
+}
+
+// WithMiddleware wraps the Client's current transport with mw, so requests
+// flow through mw before reaching the transport it wraps. Options are
+// applied in order, so each successive WithMiddleware wraps (and becomes
+// outermost to) every middleware installed before it — stack a middleware
+// built on LoggingTransport, then one built on RetryTransport, to have
+// logging observe every retry attempt.
+func WithMiddleware(mw func(http.RoundTripper) http.RoundTripper) Option {
+	return func(c *Client) {
+		base := c.client.Transport
+		if base == nil {
+			base = http.DefaultTransport
+		}
+		c.client.Transport = mw(base)
+	}
+}
+
+// WithUserAgent overrides the default User-Agent header sent with every
+// request.
+func WithUserAgent(ua string) Option {
+	return func(c *Client) {
+		c.userAgent = ua
+	}
+}
@@ -0,0 +1,133 @@
+package robinhood
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"time"
+)
+
+// pageResponse is the envelope Robinhood wraps every list endpoint's
+// results in.
+type pageResponse[T any] struct {
+	Next     string `json:"next"`
+	Previous string `json:"previous"`
+	Results  []T    `json:"results"`
+}
+
+// Paginator walks a Robinhood list endpoint's "next" cursor one page at a
+// time. Call Next until it returns false, reading each item with Value, or
+// use All to collect every page at once.
+type Paginator[T any] struct {
+	client  *Client
+	nextURL string
+
+	page []T
+	idx  int
+	err  error
+}
+
+// PaginatorOption configures a Paginator.
+type PaginatorOption func(*url.Values)
+
+// PageSize sets the page_size query parameter on the first request. Later
+// pages reuse whatever page size Robinhood's "next" cursor encodes.
+func PageSize(n int) PaginatorOption {
+	return func(v *url.Values) {
+		v.Set("page_size", fmt.Sprintf("%d", n))
+	}
+}
+
+// newPaginator builds a Paginator over firstURL, a path relative to
+// Client.BaseURL.
+func newPaginator[T any](c *Client, firstURL string, opts ...PaginatorOption) *Paginator[T] {
+	q := url.Values{}
+	for _, opt := range opts {
+		opt(&q)
+	}
+	if encoded := q.Encode(); encoded != "" {
+		firstURL = firstURL + "?" + encoded
+	}
+
+	p := &Paginator[T]{client: c, nextURL: firstURL}
+	if resolved, err := c.resolveUrl(firstURL); err == nil {
+		p.nextURL = resolved
+	} else {
+		p.err = err
+	}
+	return p
+}
+
+// Next advances to the next item, fetching a new page if the current one
+// is exhausted. It returns false when there are no more items or an error
+// occurred; check Err to distinguish the two.
+func (p *Paginator[T]) Next(ctx context.Context) bool {
+	if p.err != nil {
+		return false
+	}
+
+	for p.idx >= len(p.page) {
+		if p.nextURL == "" {
+			return false
+		}
+		if !p.fetch(ctx) {
+			return false
+		}
+	}
+
+	p.idx++
+	return true
+}
+
+// fetch retrieves the page at p.nextURL, handling rate-limit errors by
+// waiting the requested RetryAfter and retrying once.
+func (p *Paginator[T]) fetch(ctx context.Context) bool {
+	req, err := p.client.NewRequestWithFullUrlContext(ctx, "GET", p.nextURL, nil)
+	if err != nil {
+		p.err = err
+		return false
+	}
+
+	var out pageResponse[T]
+	_, err = p.client.DoContext(ctx, req, &out)
+	if rlErr, ok := err.(*RateLimitError); ok {
+		select {
+		case <-ctx.Done():
+			p.err = ctx.Err()
+			return false
+		case <-time.After(rlErr.RetryAfter):
+		}
+		_, err = p.client.DoContext(ctx, req, &out)
+	}
+	if err != nil {
+		p.err = err
+		return false
+	}
+
+	p.page = out.Results
+	p.idx = 0
+	p.nextURL = out.Next
+	return true
+}
+
+// Value returns the item Next just advanced to.
+func (p *Paginator[T]) Value() T {
+	return p.page[p.idx-1]
+}
+
+// Err returns the error, if any, that stopped iteration.
+func (p *Paginator[T]) Err() error {
+	return p.err
+}
+
+// All drains the Paginator, returning every remaining item.
+func (p *Paginator[T]) All(ctx context.Context) ([]T, error) {
+	var all []T
+	for p.Next(ctx) {
+		all = append(all, p.Value())
+	}
+	if err := p.Err(); err != nil {
+		return nil, err
+	}
+	return all, nil
+}
@@ -0,0 +1,121 @@
+package robinhood
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync/atomic"
+	"testing"
+)
+
+type paginationItem struct {
+	ID string `json:"id"`
+}
+
+func newTestPaginationClient(t *testing.T, server *httptest.Server) *Client {
+	t.Helper()
+	c := NewClient("user", "pass")
+	c.setToken(Token{AccessToken: "test-token"})
+	base, err := url.Parse(server.URL + "/")
+	if err != nil {
+		t.Fatalf("parsing test server URL: %v", err)
+	}
+	c.BaseURL = base
+	return c
+}
+
+func TestPaginatorWalksAllPages(t *testing.T) {
+	var server *httptest.Server
+	server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Query().Get("cursor") {
+		case "":
+			json.NewEncoder(w).Encode(pageResponse[paginationItem]{
+				Results: []paginationItem{{ID: "1"}, {ID: "2"}},
+				Next:    server.URL + "/items/?cursor=2",
+			})
+		case "2":
+			json.NewEncoder(w).Encode(pageResponse[paginationItem]{
+				Results: []paginationItem{{ID: "3"}},
+			})
+		default:
+			t.Errorf("unexpected cursor %q", r.URL.Query().Get("cursor"))
+		}
+	}))
+	t.Cleanup(server.Close)
+
+	client := newTestPaginationClient(t, server)
+	p := newPaginator[paginationItem](client, "items/")
+
+	items, err := p.All(context.Background())
+	if err != nil {
+		t.Fatalf("All: %v", err)
+	}
+
+	want := []string{"1", "2", "3"}
+	if len(items) != len(want) {
+		t.Fatalf("expected %d items, got %d: %+v", len(want), len(items), items)
+	}
+	for i, id := range want {
+		if items[i].ID != id {
+			t.Fatalf("item %d: expected ID %q, got %q", i, id, items[i].ID)
+		}
+	}
+}
+
+func TestPaginatorFetchRetriesAfterRateLimit(t *testing.T) {
+	var requests int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := atomic.AddInt32(&requests, 1)
+		if n == 1 {
+			w.Header().Set("Retry-After", "0")
+			w.WriteHeader(http.StatusTooManyRequests)
+			json.NewEncoder(w).Encode(map[string]interface{}{"detail": "slow down"})
+			return
+		}
+		json.NewEncoder(w).Encode(pageResponse[paginationItem]{
+			Results: []paginationItem{{ID: "1"}},
+		})
+	}))
+	t.Cleanup(server.Close)
+
+	client := newTestPaginationClient(t, server)
+	p := newPaginator[paginationItem](client, "items/")
+
+	items, err := p.All(context.Background())
+	if err != nil {
+		t.Fatalf("All: %v", err)
+	}
+	if len(items) != 1 || items[0].ID != "1" {
+		t.Fatalf("unexpected items: %+v", items)
+	}
+	if got := atomic.LoadInt32(&requests); got != 2 {
+		t.Fatalf("expected exactly one retry (2 requests), got %d", got)
+	}
+}
+
+func TestPaginatorFetchPropagatesNonRateLimitError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+		json.NewEncoder(w).Encode(map[string]interface{}{"detail": "boom"})
+	}))
+	t.Cleanup(server.Close)
+
+	client := newTestPaginationClient(t, server)
+	p := newPaginator[paginationItem](client, "items/")
+
+	if p.Next(context.Background()) {
+		t.Fatal("expected Next to return false on a server error")
+	}
+
+	var srvErr *ServerError
+	err := p.Err()
+	if err == nil {
+		t.Fatal("expected a non-nil error")
+	}
+	if !errors.As(err, &srvErr) {
+		t.Fatalf("expected *ServerError, got %T: %v", err, err)
+	}
+}
@@ -0,0 +1,46 @@
+package robinhood
+
+import "context"
+
+// PositionService provides access to the authenticated user's stock
+// positions.
+type PositionService service
+
+// Position is a single holding in a brokerage account.
+type Position struct {
+	URL        string `json:"url"`
+	Instrument string `json:"instrument"`
+	Quantity   string `json:"quantity"`
+	AverageBuy string `json:"average_buy_price"`
+}
+
+type positionsResponse struct {
+	Results []Position `json:"results"`
+}
+
+// GetPositions returns the authenticated user's current positions.
+func (s *PositionService) GetPositions() ([]Position, error) {
+	return s.GetPositionsContext(context.Background())
+}
+
+// GetPositionsContext is the context-aware form of GetPositions.
+func (s *PositionService) GetPositionsContext(ctx context.Context) ([]Position, error) {
+	req, err := s.client.NewRequestWithContext(ctx, "GET", "positions/", nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var out positionsResponse
+	_, err = s.client.DoContext(ctx, req, &out)
+	if err != nil {
+		return nil, err
+	}
+
+	return out.Results, nil
+}
+
+// ListPositions returns a Paginator that walks every position page,
+// following Robinhood's "next" cursor automatically.
+func (s *PositionService) ListPositions(opts ...PaginatorOption) *Paginator[Position] {
+	return newPaginator[Position](s.client, "positions/", opts...)
+}
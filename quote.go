@@ -0,0 +1,38 @@
+package robinhood
+
+import (
+	"context"
+	"fmt"
+)
+
+// QuoteService provides access to Robinhood's real-time quote endpoint.
+type QuoteService service
+
+// Quote is a snapshot of the last trade and bid/ask for a single symbol.
+type Quote struct {
+	Symbol         string `json:"symbol"`
+	LastTradePrice string `json:"last_trade_price"`
+	BidPrice       string `json:"bid_price"`
+	AskPrice       string `json:"ask_price"`
+}
+
+// GetQuote fetches the current quote for symbol.
+func (s *QuoteService) GetQuote(symbol string) (*Quote, error) {
+	return s.GetQuoteContext(context.Background(), symbol)
+}
+
+// GetQuoteContext is the context-aware form of GetQuote.
+func (s *QuoteService) GetQuoteContext(ctx context.Context, symbol string) (*Quote, error) {
+	req, err := s.client.NewRequestWithContext(ctx, "GET", fmt.Sprintf("quotes/%s/", symbol), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var out Quote
+	_, err = s.client.DoContext(ctx, req, &out)
+	if err != nil {
+		return nil, err
+	}
+
+	return &out, nil
+}
@@ -0,0 +1,263 @@
+package robinhood
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+)
+
+const streamHeartbeat = 15 * time.Second
+
+// defaultStreamingURL is the pricebook WebSocket endpoint StreamingService
+// dials. It is a var, rather than a const, so tests can point it at a fake
+// WS server.
+var defaultStreamingURL = "wss://api.robinhood.com/pricebook/stream/"
+
+// StreamingService opens Robinhood's pricebook WebSocket feed and exposes
+// it as channel-based subscriptions, alongside the synchronous polling
+// QuoteService.
+type StreamingService service
+
+// QuoteUpdate is a single real-time quote tick.
+type QuoteUpdate struct {
+	Symbol         string  `json:"symbol"`
+	LastTradePrice float64 `json:"last_trade_price"`
+	BidPrice       float64 `json:"bid_price"`
+	AskPrice       float64 `json:"ask_price"`
+	Timestamp      time.Time
+}
+
+// TradePrint is a single executed-trade tick.
+type TradePrint struct {
+	Symbol   string  `json:"symbol"`
+	Price    float64 `json:"price"`
+	Size     int64   `json:"size"`
+	Exchange string  `json:"exchange"`
+}
+
+// BookUpdate is a single level-2 order-book change.
+type BookUpdate struct {
+	Symbol string  `json:"symbol"`
+	Side   string  `json:"side"`
+	Price  float64 `json:"price"`
+	Size   int64   `json:"size"`
+}
+
+// streamMessage is the envelope Robinhood wraps every pricebook message
+// in; Channel selects which of the typed fields below is populated.
+type streamMessage struct {
+	Channel string          `json:"channel"`
+	Symbol  string          `json:"symbol"`
+	Data    json.RawMessage `json:"data"`
+}
+
+// Subscription is a live channel-based subscription to one of the
+// pricebook streams. The subscription reconnects automatically on
+// dropped connections and refreshes its auth token on auth failures; call
+// Close to stop it.
+type Subscription struct {
+	closeOnce sync.Once
+	cancel    context.CancelFunc
+	done      chan struct{}
+}
+
+// Close stops the subscription and closes its update channel.
+func (s *Subscription) Close() {
+	s.closeOnce.Do(func() {
+		s.cancel()
+		<-s.done
+	})
+}
+
+// SubscribeQuotes opens a streaming subscription for real-time quote
+// updates on symbols.
+func (s *StreamingService) SubscribeQuotes(ctx context.Context, symbols []string) (*Subscription, <-chan QuoteUpdate, error) {
+	updates := make(chan QuoteUpdate)
+	sub, err := s.subscribe(ctx, "quotes", symbols, func(ctx context.Context, msg streamMessage) {
+		var q QuoteUpdate
+		if err := json.Unmarshal(msg.Data, &q); err != nil {
+			return
+		}
+		q.Symbol = msg.Symbol
+		q.Timestamp = time.Now()
+		select {
+		case updates <- q:
+		case <-ctx.Done():
+		}
+	}, func() { close(updates) })
+	return sub, updates, err
+}
+
+// SubscribeTrades opens a streaming subscription for trade prints on
+// symbols.
+func (s *StreamingService) SubscribeTrades(ctx context.Context, symbols []string) (*Subscription, <-chan TradePrint, error) {
+	prints := make(chan TradePrint)
+	sub, err := s.subscribe(ctx, "trades", symbols, func(ctx context.Context, msg streamMessage) {
+		var t TradePrint
+		if err := json.Unmarshal(msg.Data, &t); err != nil {
+			return
+		}
+		t.Symbol = msg.Symbol
+		select {
+		case prints <- t:
+		case <-ctx.Done():
+		}
+	}, func() { close(prints) })
+	return sub, prints, err
+}
+
+// SubscribeBook opens a streaming subscription for level-2 order-book
+// updates on symbols.
+func (s *StreamingService) SubscribeBook(ctx context.Context, symbols []string) (*Subscription, <-chan BookUpdate, error) {
+	book := make(chan BookUpdate)
+	sub, err := s.subscribe(ctx, "book", symbols, func(ctx context.Context, msg streamMessage) {
+		var b BookUpdate
+		if err := json.Unmarshal(msg.Data, &b); err != nil {
+			return
+		}
+		b.Symbol = msg.Symbol
+		select {
+		case book <- b:
+		case <-ctx.Done():
+		}
+	}, func() { close(book) })
+	return sub, book, err
+}
+
+// subscribe drives the reconnect/heartbeat loop for a single channel and
+// dispatches decoded messages to onMessage, passing it the subscription's
+// own cancellable context (not the caller's ctx) so a message dispatch
+// blocked on a full channel unblocks as soon as Subscription.Close cancels
+// it. onClosed runs once, after the loop has exited, to close the caller's
+// update channel.
+func (s *StreamingService) subscribe(ctx context.Context, channel string, symbols []string, onMessage func(context.Context, streamMessage), onClosed func()) (*Subscription, error) {
+	ctx, cancel := context.WithCancel(ctx)
+	done := make(chan struct{})
+
+	go func() {
+		defer close(done)
+		defer onClosed()
+		s.runLoop(ctx, channel, symbols, onMessage)
+	}()
+
+	return &Subscription{cancel: cancel, done: done}, nil
+}
+
+// runLoop connects, subscribes, and relays messages until ctx is
+// cancelled, reconnecting (and refreshing the auth token on auth
+// failures) whenever the connection drops.
+func (s *StreamingService) runLoop(ctx context.Context, channel string, symbols []string, onMessage func(context.Context, streamMessage)) {
+	backoff := time.Second
+
+	for {
+		if ctx.Err() != nil {
+			return
+		}
+
+		err := s.runOnce(ctx, channel, symbols, onMessage)
+		if ctx.Err() != nil {
+			return
+		}
+		if err != nil {
+			if _, ok := err.(*AuthError); ok {
+				s.client.refreshAuthToken(ctx)
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(backoff):
+		}
+		if backoff < 30*time.Second {
+			backoff *= 2
+		}
+	}
+}
+
+// runOnce dials the pricebook WebSocket, subscribes to channel/symbols,
+// and relays messages to onMessage until the connection drops or ctx is
+// cancelled.
+func (s *StreamingService) runOnce(ctx context.Context, channel string, symbols []string, onMessage func(context.Context, streamMessage)) error {
+	tok, err := s.client.authTokenContext(ctx)
+	if err != nil {
+		return err
+	}
+
+	header := http.Header{}
+	header.Set("Authorization", fmt.Sprintf("Bearer %v", tok))
+
+	conn, err := dialWebSocket(defaultStreamingURL, header)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	sub, err := json.Marshal(struct {
+		Action  string   `json:"action"`
+		Channel string   `json:"channel"`
+		Symbols []string `json:"symbols"`
+	}{Action: "subscribe", Channel: channel, Symbols: symbols})
+	if err != nil {
+		return err
+	}
+	if err := conn.WriteText(sub); err != nil {
+		return err
+	}
+
+	msgs := make(chan streamMessage)
+	readErr := make(chan error, 1)
+	go func() {
+		defer close(msgs)
+		for {
+			op, payload, err := conn.ReadMessage()
+			if err != nil {
+				readErr <- err
+				return
+			}
+			if op != wsOpText {
+				continue
+			}
+			var msg streamMessage
+			if err := json.Unmarshal(payload, &msg); err != nil {
+				continue
+			}
+			select {
+			case msgs <- msg:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	heartbeat := time.NewTicker(streamHeartbeat)
+	defer heartbeat.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case err := <-readErr:
+			return err
+		case msg, ok := <-msgs:
+			if !ok {
+				// The reader sends its error before closing msgs; if there
+				// is none, it stopped because ctx was cancelled.
+				select {
+				case err := <-readErr:
+					return err
+				default:
+					return nil
+				}
+			}
+			onMessage(ctx, msg)
+		case <-heartbeat.C:
+			if err := conn.WritePing(); err != nil {
+				return err
+			}
+		}
+	}
+}
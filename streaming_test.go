@@ -0,0 +1,156 @@
+package robinhood
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+// fakeWSServer is a minimal WebSocket server good enough to drive
+// StreamingService in tests: it accepts exactly one Upgrade per Hijack and
+// hands the resulting connection back as a wsConn so tests can read the
+// subscribe message and push fake updates with the same framing the real
+// client uses.
+type fakeWSServer struct {
+	server *httptest.Server
+	conns  chan *wsConn
+}
+
+func newFakeWSServer(t *testing.T) *fakeWSServer {
+	t.Helper()
+	f := &fakeWSServer{conns: make(chan *wsConn, 1)}
+	f.server = httptest.NewServer(http.HandlerFunc(f.handle))
+	t.Cleanup(f.server.Close)
+	return f
+}
+
+func (f *fakeWSServer) handle(w http.ResponseWriter, r *http.Request) {
+	hj, ok := w.(http.Hijacker)
+	if !ok {
+		http.Error(w, "hijack unsupported", http.StatusInternalServerError)
+		return
+	}
+	conn, rw, err := hj.Hijack()
+	if err != nil {
+		return
+	}
+
+	accept := acceptKey(r.Header.Get("Sec-WebSocket-Key"))
+	rw.WriteString("HTTP/1.1 101 Switching Protocols\r\n" +
+		"Upgrade: websocket\r\n" +
+		"Connection: Upgrade\r\n" +
+		"Sec-WebSocket-Accept: " + accept + "\r\n\r\n")
+	rw.Flush()
+
+	f.conns <- &wsConn{conn: conn, br: rw.Reader}
+}
+
+func (f *fakeWSServer) wsURL() string {
+	return "ws" + strings.TrimPrefix(f.server.URL, "http")
+}
+
+// accept blocks until a client has connected, or fails the test after a
+// short timeout.
+func (f *fakeWSServer) accept(t *testing.T) *wsConn {
+	t.Helper()
+	select {
+	case c := <-f.conns:
+		return c
+	case <-time.After(2 * time.Second):
+		t.Fatal("timed out waiting for a client connection")
+		return nil
+	}
+}
+
+func newTestStreamingClient() *Client {
+	c := NewClient("user", "pass")
+	c.setToken(Token{AccessToken: "test-token"})
+	return c
+}
+
+func TestSubscribeQuotesDeliversUpdates(t *testing.T) {
+	fake := newFakeWSServer(t)
+	orig := defaultStreamingURL
+	defaultStreamingURL = fake.wsURL()
+	defer func() { defaultStreamingURL = orig }()
+
+	client := newTestStreamingClient()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	sub, updates, err := client.Streaming.SubscribeQuotes(ctx, []string{"AAPL"})
+	if err != nil {
+		t.Fatalf("SubscribeQuotes: %v", err)
+	}
+	defer sub.Close()
+
+	server := fake.accept(t)
+
+	// Drain the subscribe request the client sent before replying.
+	if _, _, err := server.ReadMessage(); err != nil {
+		t.Fatalf("reading subscribe message: %v", err)
+	}
+
+	if err := server.WriteText([]byte(`{"channel":"quotes","symbol":"AAPL","data":{"symbol":"AAPL","last_trade_price":190.5,"bid_price":190.4,"ask_price":190.6}}`)); err != nil {
+		t.Fatalf("writing quote update: %v", err)
+	}
+
+	select {
+	case q := <-updates:
+		if q.Symbol != "AAPL" || q.LastTradePrice != 190.5 {
+			t.Fatalf("unexpected update: %+v", q)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("timed out waiting for quote update")
+	}
+}
+
+func TestSubscriptionCloseUnblocksPendingDispatch(t *testing.T) {
+	fake := newFakeWSServer(t)
+	orig := defaultStreamingURL
+	defaultStreamingURL = fake.wsURL()
+	defer func() { defaultStreamingURL = orig }()
+
+	client := newTestStreamingClient()
+
+	sub, updates, err := client.Streaming.SubscribeQuotes(context.Background(), []string{"AAPL"})
+	if err != nil {
+		t.Fatalf("SubscribeQuotes: %v", err)
+	}
+
+	server := fake.accept(t)
+	if _, _, err := server.ReadMessage(); err != nil {
+		t.Fatalf("reading subscribe message: %v", err)
+	}
+
+	// Push an update but never read it from `updates`, so the dispatch
+	// goroutine parks on the unbuffered channel send.
+	if err := server.WriteText([]byte(`{"channel":"quotes","symbol":"AAPL","data":{"symbol":"AAPL"}}`)); err != nil {
+		t.Fatalf("writing quote update: %v", err)
+	}
+	time.Sleep(50 * time.Millisecond)
+
+	closed := make(chan struct{})
+	go func() {
+		sub.Close()
+		close(closed)
+	}()
+
+	select {
+	case <-closed:
+	case <-time.After(2 * time.Second):
+		t.Fatal("Subscription.Close did not return; dispatch goroutine is stuck")
+	}
+
+	select {
+	case _, ok := <-updates:
+		if ok {
+			t.Fatalf("expected updates channel to be closed")
+		}
+	default:
+	}
+}
@@ -0,0 +1,55 @@
+package robinhood
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+)
+
+// TradeService places and manages orders.
+type TradeService service
+
+// Order is a single equity order.
+type Order struct {
+	URL        string `json:"url"`
+	Instrument string `json:"instrument"`
+	Side       string `json:"side"`
+	Quantity   string `json:"quantity"`
+	State      string `json:"state"`
+}
+
+// PlaceOrder submits a basic market or limit order.
+func (s *TradeService) PlaceOrder(accountURL, instrumentURL, symbol, side, orderType string, quantity int, price string) (*Order, error) {
+	return s.PlaceOrderContext(context.Background(), accountURL, instrumentURL, symbol, side, orderType, quantity, price)
+}
+
+// PlaceOrderContext is the context-aware form of PlaceOrder.
+func (s *TradeService) PlaceOrderContext(ctx context.Context, accountURL, instrumentURL, symbol, side, orderType string, quantity int, price string) (*Order, error) {
+	data := url.Values{
+		"account":       {accountURL},
+		"instrument":    {instrumentURL},
+		"symbol":        {symbol},
+		"side":          {side},
+		"type":          {orderType},
+		"quantity":      {fmt.Sprintf("%d", quantity)},
+		"trigger":       {"immediate"},
+		"time_in_force": {"gfd"},
+	}
+	if price != "" {
+		data.Set("price", price)
+	}
+
+	var out Order
+	_, err := s.client.PostContext(ctx, "orders/", data, &out)
+	if err != nil {
+		return nil, err
+	}
+
+	return &out, nil
+}
+
+// ListOrders returns a Paginator that walks the authenticated user's order
+// history, following the "next" cursor automatically.
+func (s *TradeService) ListOrders(opts ...PaginatorOption) *Paginator[Order] {
+	return newPaginator[Order](s.client, "orders/", opts...)
+}
@@ -0,0 +1,267 @@
+package robinhood
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/rand"
+	"crypto/sha1"
+	"crypto/tls"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// This file implements just enough of RFC 6455 to drive Robinhood's
+// streaming endpoints: a client-side handshake, writing of text/ping/close
+// frames, and reading of frames of any type. Incoming pings are not
+// answered with pongs. It intentionally does not pull in a third-party WebSocket
+// library so the package has no dependencies beyond the standard library.
+
+const wsGUID = "258EAFA5-E914-47DA-95CA-C5AB0DC85B11"
+
+// wsOpcode identifies a WebSocket frame type.
+type wsOpcode byte
+
+const (
+	wsOpText   wsOpcode = 0x1
+	wsOpClose  wsOpcode = 0x8
+	wsOpPing   wsOpcode = 0x9
+	wsOpPong   wsOpcode = 0xA
+	wsOpBinary wsOpcode = 0x2
+)
+
+// wsConn is a minimal client-side WebSocket connection.
+type wsConn struct {
+	conn net.Conn
+	br   *bufio.Reader
+}
+
+// dialWebSocket performs the HTTP Upgrade handshake against urlStr (a
+// ws:// or wss:// URL) and returns an established wsConn.
+func dialWebSocket(urlStr string, header http.Header) (*wsConn, error) {
+	u, err := url.Parse(urlStr)
+	if err != nil {
+		return nil, err
+	}
+
+	addr := u.Host
+	if !strings.Contains(addr, ":") {
+		if u.Scheme == "wss" {
+			addr += ":443"
+		} else {
+			addr += ":80"
+		}
+	}
+
+	var conn net.Conn
+	if u.Scheme == "wss" {
+		conn, err = tlsDial(addr, u.Hostname())
+	} else {
+		conn, err = net.Dial("tcp", addr)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	key := make([]byte, 16)
+	if _, err := rand.Read(key); err != nil {
+		conn.Close()
+		return nil, err
+	}
+	secKey := base64.StdEncoding.EncodeToString(key)
+
+	req := fmt.Sprintf("GET %s HTTP/1.1\r\n", u.RequestURI())
+	req += fmt.Sprintf("Host: %s\r\n", u.Host)
+	req += "Upgrade: websocket\r\n"
+	req += "Connection: Upgrade\r\n"
+	req += fmt.Sprintf("Sec-WebSocket-Key: %s\r\n", secKey)
+	req += "Sec-WebSocket-Version: 13\r\n"
+	for k, vs := range header {
+		for _, v := range vs {
+			req += fmt.Sprintf("%s: %s\r\n", k, v)
+		}
+	}
+	req += "\r\n"
+
+	if _, err := conn.Write([]byte(req)); err != nil {
+		conn.Close()
+		return nil, err
+	}
+
+	br := bufio.NewReader(conn)
+	resp, err := http.ReadResponse(br, &http.Request{Method: "GET", URL: u})
+	if err != nil {
+		conn.Close()
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusSwitchingProtocols {
+		conn.Close()
+		return nil, classifyHandshakeError(resp)
+	}
+
+	expectedAccept := acceptKey(secKey)
+	if resp.Header.Get("Sec-WebSocket-Accept") != expectedAccept {
+		conn.Close()
+		return nil, fmt.Errorf("robinhood: websocket handshake failed: bad Sec-WebSocket-Accept")
+	}
+
+	return &wsConn{conn: conn, br: br}, nil
+}
+
+func tlsDial(addr, serverName string) (net.Conn, error) {
+	return tls.Dial("tcp", addr, &tls.Config{ServerName: serverName})
+}
+
+// classifyHandshakeError turns a failed WebSocket Upgrade response with
+// status 401/403 or 429 into the same AuthError or RateLimitError that
+// CheckResponse produces for REST calls, so callers (in particular the
+// streaming reconnect loop) can detect an expired/invalid token via
+// errors.As/errors.Is instead of string-matching a status line. Any other
+// status becomes a plain error carrying the status line.
+func classifyHandshakeError(resp *http.Response) error {
+	var body interface{}
+	data, err := io.ReadAll(resp.Body)
+	if err == nil && data != nil {
+		json.Unmarshal(data, &body)
+	}
+
+	base := &APIError{Response: resp, Body: body}
+
+	switch resp.StatusCode {
+	case http.StatusUnauthorized, http.StatusForbidden:
+		return &AuthError{APIError: base, Challenge: parseMFAChallenge(body)}
+	case http.StatusTooManyRequests:
+		return &RateLimitError{APIError: base, RetryAfter: parseRetryAfter(resp)}
+	default:
+		return fmt.Errorf("robinhood: websocket handshake failed: %s", resp.Status)
+	}
+}
+
+func acceptKey(secKey string) string {
+	h := sha1.New()
+	io.WriteString(h, secKey+wsGUID)
+	return base64.StdEncoding.EncodeToString(h.Sum(nil))
+}
+
+// SetDeadline applies a read/write deadline to the underlying connection.
+func (c *wsConn) SetDeadline(t time.Time) error {
+	return c.conn.SetDeadline(t)
+}
+
+// Close sends a close frame and closes the underlying connection.
+func (c *wsConn) Close() error {
+	c.writeFrame(wsOpClose, nil)
+	return c.conn.Close()
+}
+
+// WriteText sends payload as a single, masked text frame.
+func (c *wsConn) WriteText(payload []byte) error {
+	return c.writeFrame(wsOpText, payload)
+}
+
+// WritePing sends a masked ping frame.
+func (c *wsConn) WritePing() error {
+	return c.writeFrame(wsOpPing, nil)
+}
+
+func (c *wsConn) writeFrame(op wsOpcode, payload []byte) error {
+	var buf bytes.Buffer
+	buf.WriteByte(0x80 | byte(op)) // FIN + opcode
+
+	mask := make([]byte, 4)
+	if _, err := rand.Read(mask); err != nil {
+		return err
+	}
+
+	n := len(payload)
+	switch {
+	case n <= 125:
+		buf.WriteByte(0x80 | byte(n))
+	case n <= 65535:
+		buf.WriteByte(0x80 | 126)
+		binary.Write(&buf, binary.BigEndian, uint16(n))
+	default:
+		buf.WriteByte(0x80 | 127)
+		binary.Write(&buf, binary.BigEndian, uint64(n))
+	}
+
+	buf.Write(mask)
+	masked := make([]byte, n)
+	for i, b := range payload {
+		masked[i] = b ^ mask[i%4]
+	}
+	buf.Write(masked)
+
+	_, err := c.conn.Write(buf.Bytes())
+	return err
+}
+
+// ReadMessage reads and reassembles the next message (handling
+// continuation frames), returning its opcode and payload.
+func (c *wsConn) ReadMessage() (wsOpcode, []byte, error) {
+	var op wsOpcode
+	var payload []byte
+
+	for {
+		header := make([]byte, 2)
+		if _, err := io.ReadFull(c.br, header); err != nil {
+			return 0, nil, err
+		}
+
+		fin := header[0]&0x80 != 0
+		frameOp := wsOpcode(header[0] & 0x0F)
+		masked := header[1]&0x80 != 0
+		length := uint64(header[1] & 0x7F)
+
+		switch length {
+		case 126:
+			ext := make([]byte, 2)
+			if _, err := io.ReadFull(c.br, ext); err != nil {
+				return 0, nil, err
+			}
+			length = uint64(binary.BigEndian.Uint16(ext))
+		case 127:
+			ext := make([]byte, 8)
+			if _, err := io.ReadFull(c.br, ext); err != nil {
+				return 0, nil, err
+			}
+			length = binary.BigEndian.Uint64(ext)
+		}
+
+		var mask []byte
+		if masked {
+			mask = make([]byte, 4)
+			if _, err := io.ReadFull(c.br, mask); err != nil {
+				return 0, nil, err
+			}
+		}
+
+		data := make([]byte, length)
+		if _, err := io.ReadFull(c.br, data); err != nil {
+			return 0, nil, err
+		}
+		if masked {
+			for i := range data {
+				data[i] ^= mask[i%4]
+			}
+		}
+
+		if frameOp != 0 {
+			op = frameOp
+		}
+		payload = append(payload, data...)
+
+		if fin {
+			return op, payload, nil
+		}
+	}
+}